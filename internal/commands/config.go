@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configKeyAliases maps a flag/env name to the differently-spelled key some
+// config files use for the same setting. Both loadConfig (RegisterAlias) and
+// bindEnv (BindPFlag/BindEnv) must agree on this mapping: viper.RegisterAlias
+// makes every lookup of the flag name resolve to searching the config key
+// across ALL tiers, not just the config file, so the pflag and environment
+// variable must also be bound under the config key - binding them under the
+// flag name instead leaves them at a key viper's alias resolution never
+// looks at again, and they're silently ignored.
+var configKeyAliases = map[string]string{
+	"policy-checksum": "checksum",
+	"output-file":     "output_file",
+}
+
+// loadConfig reads the config file named by the '--config' flag, or, if
+// unset, searches for one of conftest's default config file locations:
+// './conftest.yaml', './.conftest.yaml', and
+// '$XDG_CONFIG_HOME/conftest/config.yaml'. It must run before bindEnv so
+// that config file values are loaded before viper.BindPFlag is called for
+// each flag - otherwise viper would treat the flag's default as an
+// explicitly set value and the config file would never be consulted.
+//
+// Precedence once both are loaded: explicit flag > environment variable >
+// config file > flag default.
+func loadConfig(cmd *cobra.Command) error {
+	for flagName, configKey := range configKeyAliases {
+		viper.RegisterAlias(flagName, configKey)
+	}
+
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("get config flag: %w", err)
+	}
+
+	if configPath != "" {
+		viper.SetConfigFile(configPath)
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("read config file %s: %w", configPath, err)
+		}
+
+		return nil
+	}
+
+	for _, candidate := range defaultConfigPaths() {
+		if _, err := os.Stat(candidate); err != nil {
+			continue
+		}
+
+		viper.SetConfigFile(candidate)
+		if err := viper.ReadInConfig(); err != nil {
+			var notFound viper.ConfigFileNotFoundError
+			if errors.As(err, &notFound) {
+				continue
+			}
+			return fmt.Errorf("read config file %s: %w", candidate, err)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func defaultConfigPaths() []string {
+	paths := []string{"conftest.yaml", ".conftest.yaml"}
+	if xdgHome := os.Getenv("XDG_CONFIG_HOME"); xdgHome != "" {
+		paths = append(paths, filepath.Join(xdgHome, "conftest", "config.yaml"))
+	}
+
+	return paths
+}
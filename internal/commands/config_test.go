@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newConfigTestCommand builds a minimal command with an "output" flag
+// (default "") so precedence can be exercised without depending on the
+// real test/verify commands.
+func newConfigTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "configtest"}
+	cmd.Flags().String("output", "", "output format")
+	cmd.Flags().String("config", "", "path to a config file")
+	return cmd
+}
+
+func resetViper(t *testing.T) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+}
+
+func TestLoadConfigPrecedenceConfigFileWins(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	if err := os.WriteFile(configPath, []byte("output: from-config\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cmd := newConfigTestCommand()
+	if err := cmd.Flags().Set("config", configPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	if err := loadConfig(cmd); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := bindEnv(cmd, "CONFTEST", "output"); err != nil {
+		t.Fatalf("bind env: %v", err)
+	}
+
+	if got := viper.GetString("output"); got != "from-config" {
+		t.Fatalf("expected config value %q, got %q", "from-config", got)
+	}
+}
+
+func TestLoadConfigPrecedenceEnvBeatsConfig(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	if err := os.WriteFile(configPath, []byte("output: from-config\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	t.Setenv("CONFTEST_OUTPUT", "from-env")
+
+	cmd := newConfigTestCommand()
+	if err := cmd.Flags().Set("config", configPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	if err := loadConfig(cmd); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := bindEnv(cmd, "CONFTEST", "output"); err != nil {
+		t.Fatalf("bind env: %v", err)
+	}
+
+	if got := viper.GetString("output"); got != "from-env" {
+		t.Fatalf("expected env value %q, got %q", "from-env", got)
+	}
+}
+
+func TestLoadConfigPrecedenceFlagBeatsEnvAndConfig(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	if err := os.WriteFile(configPath, []byte("output: from-config\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	t.Setenv("CONFTEST_OUTPUT", "from-env")
+
+	cmd := newConfigTestCommand()
+	if err := cmd.Flags().Set("config", configPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+	if err := cmd.Flags().Set("output", "from-flag"); err != nil {
+		t.Fatalf("set output flag: %v", err)
+	}
+
+	if err := loadConfig(cmd); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := bindEnv(cmd, "CONFTEST", "output"); err != nil {
+		t.Fatalf("bind env: %v", err)
+	}
+
+	if got := viper.GetString("output"); got != "from-flag" {
+		t.Fatalf("expected flag value %q, got %q", "from-flag", got)
+	}
+}
+
+func TestLoadConfigPrecedenceDefaultWhenNothingSet(t *testing.T) {
+	resetViper(t)
+
+	cmd := newConfigTestCommand()
+
+	if err := loadConfig(cmd); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := bindEnv(cmd, "CONFTEST", "output"); err != nil {
+		t.Fatalf("bind env: %v", err)
+	}
+
+	if got := viper.GetString("output"); got != "" {
+		t.Fatalf("expected the flag default %q, got %q", "", got)
+	}
+}
+
+func TestLoadConfigChecksumAlias(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	if err := os.WriteFile(configPath, []byte("checksum:\n  - https://example.com/a.rego=deadbeef\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cmd := newConfigTestCommand()
+	cmd.Flags().StringSlice("policy-checksum", nil, "policy checksums")
+	if err := cmd.Flags().Set("config", configPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	if err := loadConfig(cmd); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := bindEnv(cmd, "CONFTEST", "policy-checksum"); err != nil {
+		t.Fatalf("bind env: %v", err)
+	}
+
+	got := viper.GetStringSlice("policy-checksum")
+	if len(got) != 1 || got[0] != "https://example.com/a.rego=deadbeef" {
+		t.Fatalf("expected the config's 'checksum' key to resolve to 'policy-checksum', got %v", got)
+	}
+}
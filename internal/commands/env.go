@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// bindEnv binds each of flagNames to both the given cobra command and to an
+// environment variable of the form PREFIX_FLAG_NAME (dashes become
+// underscores), so that any flag left unset on the command line falls back
+// to its environment variable.
+func bindEnv(cmd *cobra.Command, prefix string, flagNames ...string) error {
+	viper.SetEnvPrefix(prefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+
+	for _, name := range flagNames {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("bind env: no such flag %q", name)
+		}
+
+		// A flag with a config-file alias must be bound under the real key
+		// the alias resolves to (see configKeyAliases): viper's alias
+		// resolution redirects every lookup of name to that key across all
+		// tiers, so binding the pflag/env under name itself would leave them
+		// somewhere find() never looks again. The alias is registered here too,
+		// so commands that never call loadConfig still resolve name (and
+		// viper.Unmarshal still fills its field) through the real key.
+		viperKey := name
+		if realKey, ok := configKeyAliases[name]; ok {
+			viper.RegisterAlias(name, realKey)
+			viperKey = realKey
+		}
+
+		if err := viper.BindPFlag(viperKey, flag); err != nil {
+			return fmt.Errorf("bind flag %q: %w", name, err)
+		}
+
+		if err := viper.BindEnv(viperKey, envVarName(prefix, name)); err != nil {
+			return fmt.Errorf("bind env %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// decorateEnvUsage appends each flag's resolved environment variable name to
+// its usage string so it shows up in --help output. This must run at
+// flag-registration time, in the NewXCommand constructor, rather than from
+// PreRunE: cobra serves --help directly from Command.execute() before
+// PreRunE ever runs, so a mutation made there would never be visible to a
+// user running --help.
+func decorateEnvUsage(cmd *cobra.Command, prefix string, flagNames ...string) {
+	for _, name := range flagNames {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		flag.Usage = fmt.Sprintf("%s (env: %s)", flag.Usage, envVarName(prefix, name))
+	}
+}
+
+// envVarName returns the environment variable that backs flag name under
+// prefix, e.g. envVarName("CONFTEST", "policy-checksum") = "CONFTEST_POLICY_CHECKSUM".
+func envVarName(prefix, name string) string {
+	return fmt.Sprintf("%s_%s", prefix, strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
+}
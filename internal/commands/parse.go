@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/open-policy-agent/conftest/parser"
+	"github.com/spf13/cobra"
+)
+
+const parseDesc = `
+This command parses one or more configuration files and prints out the
+result, which is useful for debugging how conftest sees your configuration
+once it has gone through its internal parsers.
+
+	$ conftest parse deployment.yaml
+`
+
+// NewParseCommand creates a new parse command.
+func NewParseCommand() *cobra.Command {
+	flagNames := []string{"input"}
+
+	cmd := cobra.Command{
+		Use:   "parse <file> [file...]",
+		Short: "Print the parsed configuration for the given files",
+		Long:  parseDesc,
+		Args:  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindEnv(cmd, "CONFTEST", flagNames...)
+		},
+
+		RunE: func(cmd *cobra.Command, fileList []string) error {
+			configs, err := parser.ParseConfigurations(fileList)
+			if err != nil {
+				return fmt.Errorf("parse configurations: %w", err)
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "\t")
+			if err := enc.Encode(configs); err != nil {
+				return fmt.Errorf("encode configurations: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("input", "i", "", fmt.Sprintf("Input type for given source, especially useful when using conftest with stdin, valid options are: %s", parser.ValidInputs()))
+
+	decorateEnvUsage(&cmd, "CONFTEST", flagNames...)
+
+	return &cmd
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/pull"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const pullDesc = `
+Download individual policies or a bundle of policies from a registry.
+
+The pull command expects one or more urls to fetch the policies from, e.g.:
+
+	$ conftest pull instrumenta.azurecr.io/test
+`
+
+// NewPullCommand creates a new pull command.
+func NewPullCommand(ctx context.Context) *cobra.Command {
+	flagNames := []string{"policy"}
+
+	cmd := cobra.Command{
+		Use:   "pull <repository_url>",
+		Short: "Download individual policies or a bundle of policies from a registry",
+		Long:  pullDesc,
+		Args:  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindEnv(cmd, "CONFTEST", flagNames...)
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			destination := viper.GetStringSlice("policy")[0]
+			for _, url := range args {
+				if err := pull.Pull(ctx, url, destination); err != nil {
+					return fmt.Errorf("pull %s: %w", url, err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("policy", "p", []string{"policy"}, "Path to the Rego policy files directory")
+
+	decorateEnvUsage(&cmd, "CONFTEST", flagNames...)
+
+	return &cmd
+}
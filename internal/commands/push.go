@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/push"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const pushDesc = `
+Upload individual policies or a bundle of policies to a registry.
+
+The push command expects a repository url to push the policies to, e.g.:
+
+	$ conftest push instrumenta.azurecr.io/test
+`
+
+// NewPushCommand creates a new push command.
+func NewPushCommand(ctx context.Context) *cobra.Command {
+	flagNames := []string{"policy"}
+
+	cmd := cobra.Command{
+		Use:   "push <repository_url>",
+		Short: "Upload individual policies or a bundle of policies to a registry",
+		Long:  pushDesc,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindEnv(cmd, "CONFTEST", flagNames...)
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policyDirs := viper.GetStringSlice("policy")
+
+			if err := push.Push(ctx, args[0], policyDirs); err != nil {
+				return fmt.Errorf("push %s: %w", args[0], err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("policy", "p", []string{"policy"}, "Path to the Rego policy files directory")
+
+	decorateEnvUsage(&cmd, "CONFTEST", flagNames...)
+
+	return &cmd
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/open-policy-agent/conftest/internal/runner"
 	"github.com/open-policy-agent/conftest/output"
@@ -57,6 +58,13 @@ Which will return the following output:
 
 By default, it will use the regular stdout output. For a full list of available output types, see the of the '--output' flag.
 
+To write the report to a file instead of stdout, e.g. for publishing as a pipeline artifact, use the
+'--output-file' flag:
+
+	$ conftest test --output-file results.json -o json <input-file>
+
+Human-readable logs such as parse errors continue to go to stdout even when '--output-file' is set.
+
 The test command supports the '--update' flag to fetch the latest version of the policy at the given url.
 It expects one or more urls to fetch the latest policies from, e.g.:
 
@@ -64,10 +72,40 @@ It expects one or more urls to fetch the latest policies from, e.g.:
 
 See the pull command for more details on supported protocols for fetching policies.
 
+Fetched policies can be pinned to a known-good digest with the '--policy-checksum' flag, which takes
+repeatable 'url=sha256hex' pairs, or with '--checksum-file', which points to a manifest of 'sha256  path'
+lines. If any fetched artifact does not match its expected digest, or an expectation is never matched,
+conftest fails with an error instead of running the tests against unverified policy.
+
+The '--policy' flag also accepts a direct URL to a single Rego file, rather than only a local directory,
+e.g.:
+
+	$ conftest test --policy https://example.com/policies/kubernetes.rego deployment.yaml
+
+This is downloaded into a per-run temp directory alongside any local policy paths. A digest can be pinned
+either with a '?sha256=<hex>' query parameter on the URL or via '--checksum-file'.
+
 When debugging policies it can be useful to use a more verbose policy evaluation output. By using the '--trace' flag
 the output will include a detailed trace of how the policy was evaluated, e.g.
 
 	$ conftest test --trace <input-file>
+
+Instead of passing every option on the command line, a repo can commit a config file and conftest will pick
+it up automatically. By default conftest searches for './conftest.yaml', './.conftest.yaml', and
+'$XDG_CONFIG_HOME/conftest/config.yaml', in that order; '--config' points at an explicit file instead. A
+config file can set any of the runner options ('policy', 'data', 'namespace', 'combine', 'output', 'ignore',
+'update', 'checksum', 'output_file') plus a 'namespaces' map that overrides 'policy'/'data' for individual
+namespaces, e.g.:
+
+	namespace: [main, extra]
+	policy: policy
+	namespaces:
+	  extra:
+	    policy: [extra-policy]
+	    data: [extra-data]
+
+Precedence is: an explicit flag wins, then its CONFTEST_* environment variable, then the config file, then
+the flag's default.
 `
 
 // TestRun stores the compiler and store for a test run.
@@ -78,20 +116,19 @@ type TestRun struct {
 
 // NewTestCommand creates a new test command.
 func NewTestCommand(ctx context.Context) *cobra.Command {
+	flagNames := []string{"all-namespaces", "combine", "data", "fail-on-warn", "ignore", "input", "namespace", "no-color", "output", "output-file", "policy", "trace", "update", "policy-checksum", "checksum-file"}
+
 	cmd := cobra.Command{
 		Use:   "test <file> [file...]",
 		Short: "Test your configuration files using Open Policy Agent",
 		Long:  testDesc,
 		Args:  cobra.MinimumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			flagNames := []string{"all-namespaces", "combine", "data", "fail-on-warn", "ignore", "input", "namespace", "no-color", "output", "policy", "trace", "update"}
-			for _, name := range flagNames {
-				if err := viper.BindPFlag(name, cmd.Flags().Lookup(name)); err != nil {
-					return fmt.Errorf("bind flag: %w", err)
-				}
+			if err := loadConfig(cmd); err != nil {
+				return fmt.Errorf("load config: %w", err)
 			}
 
-			return nil
+			return bindEnv(cmd, "CONFTEST", flagNames...)
 		},
 
 		RunE: func(cmd *cobra.Command, fileList []string) error {
@@ -106,6 +143,16 @@ func NewTestCommand(ctx context.Context) *cobra.Command {
 			}
 
 			outputManager := output.GetOutputManager(runner.Output, !runner.NoColor)
+			if outputFile := viper.GetString("output-file"); outputFile != "" {
+				writer, err := openOutputFile(outputFile)
+				if err != nil {
+					return fmt.Errorf("open output file: %w", err)
+				}
+				defer writer.Close()
+
+				outputManager = output.GetOutputManagerWithWriter(writer, runner.Output, !runner.NoColor)
+			}
+
 			if runner.Trace {
 				outputManager = outputManager.WithTracing()
 			}
@@ -142,12 +189,36 @@ func NewTestCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().String("ignore", "", "A regex pattern which can be used for ignoring paths")
 	cmd.Flags().StringP("output", "o", "", fmt.Sprintf("Output format for conftest results - valid options are: %s", output.ValidOutputs()))
+	cmd.Flags().StringP("output-file", "O", "", "Write outputs to a file instead of stdout")
 	cmd.Flags().StringP("input", "i", "", fmt.Sprintf("Input type for given source, especially useful when using conftest with stdin, valid options are: %s", parser.ValidInputs()))
 
-	cmd.Flags().StringSliceP("policy", "p", []string{"policy"}, "Path to the Rego policy files directory")
+	cmd.Flags().StringSliceP("policy", "p", []string{"policy"}, "Path to the Rego policy files directory, or a URL to a single Rego file")
 	cmd.Flags().StringSliceP("update", "u", []string{}, "A list of URLs can be provided to the update flag, which will download before the tests run")
+	cmd.Flags().StringSlice("policy-checksum", []string{}, "A list of url=sha256hex pairs used to verify the integrity of policies fetched with --update")
+	cmd.Flags().String("checksum-file", "", "Path to a file of 'sha256  path' lines used to verify the integrity of policies fetched with --update")
 	cmd.Flags().StringSliceP("namespace", "n", []string{"main"}, "Test policies in a specific namespace")
 	cmd.Flags().StringSliceP("data", "d", []string{}, "A list of paths from which data for the rego policies will be recursively loaded")
 
+	cmd.PersistentFlags().String("config", "", "Path to a config file. Defaults to searching for ./conftest.yaml, ./.conftest.yaml, or $XDG_CONFIG_HOME/conftest/config.yaml")
+
+	decorateEnvUsage(&cmd, "CONFTEST", flagNames...)
+
 	return &cmd
 }
+
+// openOutputFile opens path for writing, creating any missing parent
+// directories, so --output-file can be pointed at a fresh path in CI.
+func openOutputFile(path string) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("create parent directories: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("create file: %w", err)
+	}
+
+	return file, nil
+}
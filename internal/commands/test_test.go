@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestTestCommandFlagBeatsConflictingConfig drives the real NewTestCommand
+// through its PreRunE with --policy-checksum (chunk0-1) and --output-file
+// (chunk0-3) set on the command line, against a config file that sets
+// conflicting values for both. This guards against the RegisterAlias/
+// BindPFlag key mismatch that silently turned both flags into no-ops
+// whenever a config file was present: the pflag and env bindings were
+// registered under the flag name, but viper's alias resolution made lookups
+// search the config key instead, so only the config file value (or nothing)
+// was ever seen.
+func TestTestCommandFlagBeatsConflictingConfig(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	configBody := "checksum:\n  - https://example.com/from-config.rego=deadbeef\noutput_file: from-config.json\n"
+	if err := os.WriteFile(configPath, []byte(configBody), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cmd := NewTestCommand(context.Background())
+	// ParseFlags merges the persistent --config flag into cmd.Flags(), as
+	// cobra does before PreRunE in a real run.
+	if err := cmd.ParseFlags([]string{"--config", configPath}); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+	if err := cmd.Flags().Set("policy-checksum", "https://example.com/from-flag.rego=cafebabe"); err != nil {
+		t.Fatalf("set policy-checksum flag: %v", err)
+	}
+	if err := cmd.Flags().Set("output-file", "from-flag.json"); err != nil {
+		t.Fatalf("set output-file flag: %v", err)
+	}
+
+	if err := cmd.PreRunE(cmd, nil); err != nil {
+		t.Fatalf("PreRunE: %v", err)
+	}
+
+	checksums := viper.GetStringSlice("policy-checksum")
+	if len(checksums) != 1 || checksums[0] != "https://example.com/from-flag.rego=cafebabe" {
+		t.Fatalf("expected the --policy-checksum flag value to win over the config file, got %v", checksums)
+	}
+
+	if got := viper.GetString("output-file"); got != "from-flag.json" {
+		t.Fatalf("expected the --output-file flag value to win over the config file, got %q", got)
+	}
+}
+
+// TestTestCommandEnvBeatsConfig confirms CONFTEST_POLICY_CHECKSUM (chunk0-2)
+// and CONFTEST_OUTPUT_FILE (chunk0-2/chunk0-3) still take effect over a
+// conflicting config file now that both are bound under the config file's
+// own key.
+func TestTestCommandEnvBeatsConfig(t *testing.T) {
+	resetViper(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "conftest.yaml")
+	configBody := "checksum:\n  - https://example.com/from-config.rego=deadbeef\noutput_file: from-config.json\n"
+	if err := os.WriteFile(configPath, []byte(configBody), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	t.Setenv("CONFTEST_POLICY_CHECKSUM", "https://example.com/from-env.rego=abad1dea")
+	t.Setenv("CONFTEST_OUTPUT_FILE", "from-env.json")
+
+	cmd := NewTestCommand(context.Background())
+	if err := cmd.ParseFlags([]string{"--config", configPath}); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	if err := cmd.PreRunE(cmd, nil); err != nil {
+		t.Fatalf("PreRunE: %v", err)
+	}
+
+	checksums := viper.GetStringSlice("policy-checksum")
+	if len(checksums) != 1 || checksums[0] != "https://example.com/from-env.rego=abad1dea" {
+		t.Fatalf("expected the env value to win over the config file, got %v", checksums)
+	}
+
+	if got := viper.GetString("output-file"); got != "from-env.json" {
+		t.Fatalf("expected the env value to win over the config file, got %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/internal/runner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const verifyDesc = `
+This command verifies the policies themselves by running any tests
+included in the policy directory, e.g. 'policy/deployment_test.rego'.
+
+The policy location defaults to the policy directory in the local folder.
+The location can be overridden with the '--policy' flag, e.g.:
+
+	$ conftest verify --policy <my-directory>
+
+The verify command supports the '--update' flag to fetch the latest version of the policy at the given url,
+just like the test command. Fetched policies can be pinned to a known-good digest with the
+'--policy-checksum' flag or '--checksum-file', which use the same enforcement path as the test command.
+`
+
+// NewVerifyCommand creates a new verify command.
+func NewVerifyCommand(ctx context.Context) *cobra.Command {
+	flagNames := []string{"policy", "data", "trace", "ignore", "update", "policy-checksum", "checksum-file"}
+
+	cmd := cobra.Command{
+		Use:   "verify",
+		Short: "Verify Rego unit tests",
+		Long:  verifyDesc,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindEnv(cmd, "CONFTEST", flagNames...)
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var runner runner.TestRunner
+			if err := viper.Unmarshal(&runner); err != nil {
+				return fmt.Errorf("unmarshal parameters: %w", err)
+			}
+
+			if err := runner.Verify(ctx); err != nil {
+				return fmt.Errorf("running verification: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolP("trace", "", false, "Enable more verbose trace output for Rego queries")
+	cmd.Flags().String("ignore", "", "A regex pattern which can be used for ignoring paths")
+	cmd.Flags().StringSliceP("policy", "p", []string{"policy"}, "Path to the Rego policy files directory")
+	cmd.Flags().StringSliceP("data", "d", []string{}, "A list of paths from which data for the rego policies will be recursively loaded")
+	cmd.Flags().StringSliceP("update", "u", []string{}, "A list of URLs can be provided to the update flag, which will download before the tests run")
+	cmd.Flags().StringSlice("policy-checksum", []string{}, "A list of url=sha256hex pairs used to verify the integrity of policies fetched with --update")
+	cmd.Flags().String("checksum-file", "", "Path to a file of 'sha256  path' lines used to verify the integrity of policies fetched with --update")
+
+	decorateEnvUsage(&cmd, "CONFTEST", flagNames...)
+
+	return &cmd
+}
@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-policy-agent/conftest/pull"
+)
+
+// verifyChecksums computes the SHA-256 digest of the downloaded file for each
+// expectation supplied via --policy-checksum or --checksum-file and confirms
+// it matches. A fetched file with no expectation is not checked. It fails
+// loudly if any artifact does not match, and also if any expectation is
+// never matched to a downloaded artifact, since that usually means a stale
+// pin or a typo in the URL.
+func verifyChecksums(urls, checksums []string, checksumFile, destination string) error {
+	expected, err := parseChecksumExpectations(urls, checksums, checksumFile)
+	if err != nil {
+		return fmt.Errorf("parse checksum expectations: %w", err)
+	}
+
+	if len(expected) == 0 {
+		return nil
+	}
+
+	matched := make(map[string]bool, len(expected))
+	for url, sum := range expected {
+		path := pull.PolicyPath(url, destination)
+		actual, err := sha256sum(path)
+		if err != nil {
+			return fmt.Errorf("checksum %s: %w", path, err)
+		}
+
+		if !strings.EqualFold(actual, sum) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, sum, actual)
+		}
+
+		matched[url] = true
+	}
+
+	var unmatched []string
+	for url := range expected {
+		if !matched[url] {
+			unmatched = append(unmatched, url)
+		}
+	}
+	if len(unmatched) > 0 {
+		return fmt.Errorf("checksum expectations never matched a downloaded policy: %s", strings.Join(unmatched, ", "))
+	}
+
+	return nil
+}
+
+// parseChecksumExpectations combines the url=sha256hex pairs from
+// --policy-checksum with any entries found in --checksum-file. An explicit
+// --policy-checksum pair always wins over a --checksum-file entry for the
+// same URL, matching the flag-beats-file precedence used everywhere else in
+// this command.
+func parseChecksumExpectations(urls, checksums []string, checksumFile string) (map[string]string, error) {
+	expected := make(map[string]string)
+
+	for _, pair := range checksums {
+		url, sum, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --policy-checksum value %q, expected url=sha256hex", pair)
+		}
+		expected[url] = sum
+	}
+
+	manifest, err := parseChecksumManifest(checksumFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, url := range urls {
+		if _, explicit := expected[url]; explicit {
+			continue
+		}
+		if sum, ok := manifest[filepath.Base(url)]; ok {
+			expected[url] = sum
+		}
+	}
+
+	return expected, nil
+}
+
+// parseChecksumManifest reads a --checksum-file manifest of 'sha256  path'
+// lines into a map keyed by the file's base name. An empty checksumFile
+// returns an empty, non-nil manifest.
+func parseChecksumManifest(checksumFile string) (map[string]string, error) {
+	manifest := make(map[string]string)
+	if checksumFile == "" {
+		return manifest, nil
+	}
+
+	f, err := os.Open(checksumFile)
+	if err != nil {
+		return nil, fmt.Errorf("open checksum file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line in checksum file: %q", line)
+		}
+		manifest[filepath.Base(fields[1])] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read checksum file: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// sha256sum returns the lowercase hex-encoded SHA-256 digest of the file at path.
+func sha256sum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
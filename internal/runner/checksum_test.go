@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/conftest/pull"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	return path
+}
+
+// writePulledFile writes contents at the same path pull.Pull would have
+// written url's download to under dir, so tests can exercise
+// verifyChecksums without an actual network fetch.
+func writePulledFile(t *testing.T, dir, url, contents string) string {
+	t.Helper()
+
+	path := pull.PolicyPath(url, dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("create parent dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	return path
+}
+
+func digest(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyChecksumsMatch(t *testing.T) {
+	dir := t.TempDir()
+	url := "https://example.com/policies/base.rego"
+	writePulledFile(t, dir, url, "package main")
+
+	checksums := []string{url + "=" + digest("package main")}
+
+	if err := verifyChecksums([]string{url}, checksums, "", dir); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestVerifyChecksumsMismatch(t *testing.T) {
+	dir := t.TempDir()
+	url := "https://example.com/policies/base.rego"
+	writePulledFile(t, dir, url, "package main")
+
+	checksums := []string{url + "=" + digest("not the real contents")}
+
+	err := verifyChecksums([]string{url}, checksums, "", dir)
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error, got nil")
+	}
+}
+
+func TestVerifyChecksumsUnmatchedExpectation(t *testing.T) {
+	dir := t.TempDir()
+	url := "https://example.com/policies/base.rego"
+	writePulledFile(t, dir, url, "package main")
+
+	// This expectation names a URL that was never downloaded, so it should
+	// never be satisfied even though base.rego matches the checksum for
+	// "other.rego".
+	checksums := []string{"https://example.com/policies/other.rego=" + digest("package main")}
+
+	err := verifyChecksums([]string{url}, checksums, "", dir)
+	if err == nil {
+		t.Fatal("expected an unmatched-expectation error, got nil")
+	}
+}
+
+// TestVerifyChecksumsDistinctBasenames confirms that two --update URLs which
+// happen to share a basename resolve to distinct paths on disk (matching
+// pull.Pull's layout) and are verified against their own, not each other's,
+// downloaded contents.
+func TestVerifyChecksumsDistinctBasenames(t *testing.T) {
+	dir := t.TempDir()
+	urlA := "https://a.example.com/policy.rego"
+	urlB := "https://b.example.com/policy.rego"
+	writePulledFile(t, dir, urlA, "package a")
+	writePulledFile(t, dir, urlB, "package b")
+
+	checksums := []string{
+		urlA + "=" + digest("package a"),
+		urlB + "=" + digest("package b"),
+	}
+
+	if err := verifyChecksums([]string{urlA, urlB}, checksums, "", dir); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestVerifyChecksumsNoExpectations(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := verifyChecksums([]string{"https://example.com/policies/base.rego"}, nil, "", dir); err != nil {
+		t.Fatalf("expected no error when no checksums are configured, got: %v", err)
+	}
+}
+
+func TestParseChecksumExpectationsFromFile(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := writeFile(t, dir, "checksums.txt", digest("package main")+"  base.rego\n")
+
+	url := "https://example.com/policies/base.rego"
+	expected, err := parseChecksumExpectations([]string{url}, nil, manifestPath)
+	if err != nil {
+		t.Fatalf("parse checksum expectations: %v", err)
+	}
+
+	if got := expected[url]; got != digest("package main") {
+		t.Fatalf("expected %s for %s, got %s", digest("package main"), url, got)
+	}
+}
+
+func TestParseChecksumExpectationsFlagBeatsFile(t *testing.T) {
+	dir := t.TempDir()
+	url := "https://example.com/policies/base.rego"
+	manifestPath := writeFile(t, dir, "checksums.txt", digest("from manifest")+"  base.rego\n")
+
+	expected, err := parseChecksumExpectations([]string{url}, []string{url + "=" + digest("from flag")}, manifestPath)
+	if err != nil {
+		t.Fatalf("parse checksum expectations: %v", err)
+	}
+
+	if got := expected[url]; got != digest("from flag") {
+		t.Fatalf("expected the --policy-checksum value to win over --checksum-file, got %s", got)
+	}
+}
+
+func TestParseChecksumExpectationsInvalidPair(t *testing.T) {
+	if _, err := parseChecksumExpectations(nil, []string{"not-a-pair"}, ""); err == nil {
+		t.Fatal("expected an error for a --policy-checksum value missing '='")
+	}
+}
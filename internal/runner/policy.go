@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+// loadPolicies parses the Rego modules found under the given policy paths,
+// skipping any path that matches the ignore pattern.
+func loadPolicies(paths []string, ignore string) (map[string]*ast.Module, error) {
+	var ignoreRe *regexp.Regexp
+	if ignore != "" {
+		re, err := regexp.Compile(ignore)
+		if err != nil {
+			return nil, fmt.Errorf("compile ignore pattern: %w", err)
+		}
+		ignoreRe = re
+	}
+
+	modules := make(map[string]*ast.Module)
+	for _, path := range paths {
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(p, ".rego") {
+				return nil
+			}
+			if ignoreRe != nil && ignoreRe.MatchString(p) {
+				return nil
+			}
+
+			contents, err := os.ReadFile(p)
+			if err != nil {
+				return fmt.Errorf("read policy %s: %w", p, err)
+			}
+
+			module, err := ast.ParseModule(p, string(contents))
+			if err != nil {
+				return fmt.Errorf("parse policy %s: %w", p, err)
+			}
+
+			modules[p] = module
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk policy path %s: %w", path, err)
+		}
+	}
+
+	return modules, nil
+}
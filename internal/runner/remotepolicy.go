@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolvePolicyPaths downloads any URL entries found in paths into a
+// per-run temp directory and swaps each one out for that directory, so the
+// rest of the policy-loading pipeline only ever deals with local paths. This
+// lets '--policy' point directly at a raw policy file, e.g.
+// 'https://example.com/policies/kubernetes.rego', instead of requiring a
+// bundle fetched ahead of time with '--update'.
+//
+// A downloaded policy can be pinned to a known digest either with a
+// '?sha256=<hex>' query parameter on the URL itself, or by matching an
+// entry in checksumFile keyed by the file's base name. The caller must
+// invoke the returned cleanup function once it is done with the compiled
+// policies.
+func resolvePolicyPaths(ctx context.Context, paths []string, checksumFile string) ([]string, func(), error) {
+	var remote []string
+	for _, path := range paths {
+		if isPolicyURL(path) {
+			remote = append(remote, path)
+		}
+	}
+
+	if len(remote) == 0 {
+		return paths, func() {}, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "conftest-remote-policy-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	manifest, err := parseChecksumManifest(checksumFile)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("parse checksum file: %w", err)
+	}
+
+	resolved := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !isPolicyURL(path) {
+			resolved = append(resolved, path)
+			continue
+		}
+
+		if err := fetchRemotePolicy(ctx, path, tempDir, manifest); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("fetch remote policy %s: %w", path, err)
+		}
+	}
+	resolved = append(resolved, tempDir)
+
+	return resolved, cleanup, nil
+}
+
+// isPolicyURL reports whether path names a remote policy rather than a local
+// file or directory. Only plain http(s) is supported: a 'git+' scheme would
+// need an actual git clone (ref resolution, subdirectories, auth), which
+// this package does not implement.
+func isPolicyURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// fetchRemotePolicy downloads the policy at rawURL into destDir, verifying
+// its digest against a '?sha256=' query parameter or an entry in manifest.
+// Each URL gets its own subdirectory, keyed by a hash of the URL, so two
+// URLs that happen to share a basename (e.g. '.../a/policy.rego' and
+// '.../b/policy.rego') don't overwrite one another on disk.
+func fetchRemotePolicy(ctx context.Context, rawURL, destDir string, manifest map[string]string) error {
+	fetchURL, expectedSum, err := splitChecksumQuery(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", fetchURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch %s: unexpected status %s", fetchURL, resp.Status)
+	}
+
+	urlDir := filepath.Join(destDir, urlDigest(rawURL))
+	if err := os.MkdirAll(urlDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", urlDir, err)
+	}
+
+	destPath := filepath.Join(urlDir, filepath.Base(fetchURL))
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("write %s: %w", destPath, err)
+	}
+
+	if expectedSum == "" {
+		expectedSum = manifest[filepath.Base(fetchURL)]
+	}
+	if expectedSum == "" {
+		return nil
+	}
+
+	actualSum, err := sha256sum(destPath)
+	if err != nil {
+		return fmt.Errorf("checksum %s: %w", destPath, err)
+	}
+	if !strings.EqualFold(actualSum, expectedSum) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedSum, actualSum)
+	}
+
+	return nil
+}
+
+// urlDigest returns a short, filesystem-safe identifier derived from rawURL,
+// used to give each downloaded policy its own subdirectory.
+func urlDigest(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// splitChecksumQuery pulls an optional 'sha256' query parameter off rawURL,
+// returning the URL to fetch and the expected digest, if any.
+func splitChecksumQuery(rawURL string) (string, string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := parsed.Query()
+	sum := query.Get("sha256")
+	query.Del("sha256")
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), sum, nil
+}
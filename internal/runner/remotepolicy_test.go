@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsPolicyURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/policies/base.rego": true,
+		"http://example.com/policies/base.rego":  true,
+		"git+https://example.com/policies.git":   false,
+		"policy":                                 false,
+		"./policy/base.rego":                     false,
+		"":                                       false,
+	}
+
+	for path, want := range cases {
+		if got := isPolicyURL(path); got != want {
+			t.Errorf("isPolicyURL(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestSplitChecksumQuery(t *testing.T) {
+	url, sum, err := splitChecksumQuery("https://example.com/policies/base.rego?sha256=deadbeef")
+	if err != nil {
+		t.Fatalf("split checksum query: %v", err)
+	}
+	if url != "https://example.com/policies/base.rego" {
+		t.Errorf("expected the sha256 param stripped from the url, got %q", url)
+	}
+	if sum != "deadbeef" {
+		t.Errorf("expected sum %q, got %q", "deadbeef", sum)
+	}
+}
+
+func TestSplitChecksumQueryNoQuery(t *testing.T) {
+	url, sum, err := splitChecksumQuery("https://example.com/policies/base.rego")
+	if err != nil {
+		t.Fatalf("split checksum query: %v", err)
+	}
+	if url != "https://example.com/policies/base.rego" {
+		t.Errorf("expected url unchanged, got %q", url)
+	}
+	if sum != "" {
+		t.Errorf("expected no sum, got %q", sum)
+	}
+}
+
+func TestSplitChecksumQueryPreservesOtherParams(t *testing.T) {
+	url, sum, err := splitChecksumQuery("https://example.com/policies/base.rego?ref=main&sha256=deadbeef")
+	if err != nil {
+		t.Fatalf("split checksum query: %v", err)
+	}
+	if url != "https://example.com/policies/base.rego?ref=main" {
+		t.Errorf("expected only sha256 stripped, got %q", url)
+	}
+	if sum != "deadbeef" {
+		t.Errorf("expected sum %q, got %q", "deadbeef", sum)
+	}
+}
+
+func TestUrlDigestStableAndDistinct(t *testing.T) {
+	a := urlDigest("https://example.com/a/policy.rego")
+	b := urlDigest("https://example.com/b/policy.rego")
+
+	if a == b {
+		t.Fatalf("expected different URLs to produce different digests, both were %q", a)
+	}
+	if got := urlDigest("https://example.com/a/policy.rego"); got != a {
+		t.Fatalf("expected urlDigest to be deterministic, got %q then %q", a, got)
+	}
+}
+
+func TestFetchRemotePolicyAvoidsBasenameCollisions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/policy.rego", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("package a"))
+	})
+	mux.HandleFunc("/b/policy.rego", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("package b"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	destDir := t.TempDir()
+
+	if err := fetchRemotePolicy(context.Background(), server.URL+"/a/policy.rego", destDir, nil); err != nil {
+		t.Fatalf("fetch a: %v", err)
+	}
+	if err := fetchRemotePolicy(context.Background(), server.URL+"/b/policy.rego", destDir, nil); err != nil {
+		t.Fatalf("fetch b: %v", err)
+	}
+
+	var found []string
+	err := filepath.Walk(destDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Base(path) == "policy.rego" {
+			found = append(found, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk dest dir: %v", err)
+	}
+
+	if len(found) != 2 {
+		t.Fatalf("expected both same-named downloads to survive in separate subdirectories, found %v", found)
+	}
+}
+
+func TestFetchRemotePolicyChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("package main"))
+	}))
+	defer server.Close()
+
+	destDir := t.TempDir()
+
+	err := fetchRemotePolicy(context.Background(), server.URL+"/base.rego?sha256=deadbeef", destDir, nil)
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error, got nil")
+	}
+}
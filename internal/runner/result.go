@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/output"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// buildCheckResult translates a Rego result set into the CheckResult shape
+// shared by the output managers.
+func buildCheckResult(path, namespace, query string, resultSet rego.ResultSet) output.CheckResult {
+	result := output.CheckResult{
+		FileName:  path,
+		Namespace: namespace,
+		Query:     query,
+	}
+
+	if len(resultSet) == 0 {
+		result.Successes = append(result.Successes, output.Result{Message: "no violations found"})
+		return result
+	}
+
+	for _, r := range resultSet {
+		for _, expression := range r.Expressions {
+			result.Failures = append(result.Failures, output.Result{Message: fmt.Sprintf("%v", expression.Value)})
+		}
+	}
+
+	return result
+}
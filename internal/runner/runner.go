@@ -0,0 +1,232 @@
+// Package runner contains the shared evaluation logic used by the test and
+// verify commands.
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/output"
+	"github.com/open-policy-agent/conftest/parser"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
+)
+
+// TestRunner is the runner used by the test and verify commands. Its fields
+// are populated by viper from command-line flags, environment variables, and
+// config files.
+type TestRunner struct {
+	Policy        []string `mapstructure:"policy"`
+	Data          []string `mapstructure:"data"`
+	Namespace     []string `mapstructure:"namespace"`
+	AllNamespaces bool     `mapstructure:"all-namespaces"`
+	Combine       bool     `mapstructure:"combine"`
+	Trace         bool     `mapstructure:"trace"`
+	Ignore        string   `mapstructure:"ignore"`
+	Input         string   `mapstructure:"input"`
+	Output        string   `mapstructure:"output"`
+	NoColor       bool     `mapstructure:"no-color"`
+	FailOnWarn    bool     `mapstructure:"fail-on-warn"`
+	Update        []string `mapstructure:"update"`
+	Checksum      []string `mapstructure:"policy-checksum"`
+	ChecksumFile  string   `mapstructure:"checksum-file"`
+
+	// Namespaces holds per-namespace overrides for the policy and data
+	// paths, keyed by namespace name. A namespace without an entry here
+	// falls back to the top-level Policy/Data paths.
+	Namespaces map[string]NamespaceConfig `mapstructure:"namespaces"`
+}
+
+// NamespaceConfig overrides the policy and data paths used to evaluate a
+// single namespace, set via a config file's 'namespaces' map.
+type NamespaceConfig struct {
+	Policy []string `mapstructure:"policy"`
+	Data   []string `mapstructure:"data"`
+}
+
+// Run executes the policy evaluation against the given files, returning one
+// CheckResult per file/namespace combination.
+func (t *TestRunner) Run(ctx context.Context, fileList []string) ([]output.CheckResult, error) {
+	if err := t.fetchPolicies(ctx); err != nil {
+		return nil, err
+	}
+
+	// The common case is no per-namespace overrides at all, so the compiler
+	// and store built from the top-level Policy/Data paths are built at most
+	// once and reused across namespaces. Only a namespace with an actual
+	// override pays to rebuild - this matters most when Policy contains a
+	// remote URL, since rebuilding means re-fetching and re-verifying it
+	// over the network.
+	var defaultCompiler *ast.Compiler
+	var defaultStore storage.Store
+	var defaultBuilt bool
+
+	var results []output.CheckResult
+	for _, namespace := range t.Namespace {
+		policyPaths, dataPaths := t.Policy, t.Data
+		overridden := false
+		if override, ok := t.Namespaces[namespace]; ok {
+			if len(override.Policy) > 0 {
+				policyPaths = override.Policy
+				overridden = true
+			}
+			if len(override.Data) > 0 {
+				dataPaths = override.Data
+				overridden = true
+			}
+		}
+
+		var compiler *ast.Compiler
+		var store storage.Store
+		if overridden {
+			var err error
+			compiler, err = t.buildCompiler(ctx, policyPaths)
+			if err != nil {
+				return nil, fmt.Errorf("build compiler for namespace %s: %w", namespace, err)
+			}
+
+			store, err = t.buildStore(dataPaths)
+			if err != nil {
+				return nil, fmt.Errorf("build store for namespace %s: %w", namespace, err)
+			}
+		} else {
+			if !defaultBuilt {
+				var err error
+				defaultCompiler, err = t.buildCompiler(ctx, policyPaths)
+				if err != nil {
+					return nil, fmt.Errorf("build compiler: %w", err)
+				}
+
+				defaultStore, err = t.buildStore(dataPaths)
+				if err != nil {
+					return nil, fmt.Errorf("build store: %w", err)
+				}
+
+				defaultBuilt = true
+			}
+
+			compiler = defaultCompiler
+			store = defaultStore
+		}
+
+		for _, file := range fileList {
+			configs, err := parser.ParseConfigurations([]string{file})
+			if err != nil {
+				return nil, fmt.Errorf("parse configurations: %w", err)
+			}
+
+			for path, config := range configs {
+				result, err := t.evaluate(ctx, compiler, store, namespace, path, config)
+				if err != nil {
+					return nil, fmt.Errorf("evaluate: %w", err)
+				}
+
+				results = append(results, result)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// Verify runs the Rego unit tests found in the policy directory. It shares
+// the same fetch-and-checksum enforcement path as Run, so policies pulled
+// with --update are verified identically whether they arrive via the test
+// or the verify command.
+func (t *TestRunner) Verify(ctx context.Context) error {
+	if err := t.fetchPolicies(ctx); err != nil {
+		return err
+	}
+
+	policyPaths, cleanup, err := resolvePolicyPaths(ctx, t.Policy, t.ChecksumFile)
+	if err != nil {
+		return fmt.Errorf("resolve policy paths: %w", err)
+	}
+	defer cleanup()
+
+	modules, err := loadPolicies(policyPaths, t.Ignore)
+	if err != nil {
+		return fmt.Errorf("load policies: %w", err)
+	}
+
+	return runPolicyTests(ctx, modules, t.Trace)
+}
+
+// fetchPolicies downloads any --update URLs into the configured --policy
+// directory and verifies their checksums before anything else touches them.
+func (t *TestRunner) fetchPolicies(ctx context.Context) error {
+	if len(t.Update) == 0 {
+		return nil
+	}
+
+	destination := t.policyDestination()
+
+	if err := download(ctx, t.Update, destination); err != nil {
+		return fmt.Errorf("update policies: %w", err)
+	}
+
+	if err := verifyChecksums(t.Update, t.Checksum, t.ChecksumFile, destination); err != nil {
+		return fmt.Errorf("verify checksums: %w", err)
+	}
+
+	return nil
+}
+
+// policyDestination returns the directory that --update downloads into: the
+// first configured --policy path, or "policy" if none was set.
+func (t *TestRunner) policyDestination() string {
+	if len(t.Policy) == 0 {
+		return "policy"
+	}
+
+	return t.Policy[0]
+}
+
+func (t *TestRunner) buildCompiler(ctx context.Context, policyPaths []string) (*ast.Compiler, error) {
+	resolvedPaths, cleanup, err := resolvePolicyPaths(ctx, policyPaths, t.ChecksumFile)
+	if err != nil {
+		return nil, fmt.Errorf("resolve policy paths: %w", err)
+	}
+	defer cleanup()
+
+	modules, err := loadPolicies(resolvedPaths, t.Ignore)
+	if err != nil {
+		return nil, fmt.Errorf("load policies: %w", err)
+	}
+
+	compiler, err := ast.CompileModules(modules)
+	if err != nil {
+		return nil, fmt.Errorf("compile modules: %w", err)
+	}
+
+	return compiler, nil
+}
+
+func (t *TestRunner) buildStore(dataPaths []string) (storage.Store, error) {
+	data, err := parser.ParseData(dataPaths)
+	if err != nil {
+		return nil, fmt.Errorf("parse data: %w", err)
+	}
+
+	return inmem.NewFromObject(data), nil
+}
+
+func (t *TestRunner) evaluate(ctx context.Context, compiler *ast.Compiler, store storage.Store, namespace, path string, config interface{}) (output.CheckResult, error) {
+	query := fmt.Sprintf("data.%s", namespace)
+	r := rego.New(
+		rego.Query(query),
+		rego.Compiler(compiler),
+		rego.Store(store),
+		rego.Input(config),
+		rego.Tracing(t.Trace),
+	)
+
+	resultSet, err := r.Eval(ctx)
+	if err != nil {
+		return output.CheckResult{}, fmt.Errorf("evaluating policy: %w", err)
+	}
+
+	return buildCheckResult(path, namespace, query, resultSet), nil
+}
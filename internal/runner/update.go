@@ -0,0 +1,20 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/conftest/pull"
+)
+
+// download fetches the given policy URLs into destination, the configured
+// --policy directory.
+func download(ctx context.Context, urls []string, destination string) error {
+	for _, url := range urls {
+		if err := pull.Pull(ctx, url, destination); err != nil {
+			return fmt.Errorf("pull %s: %w", url, err)
+		}
+	}
+
+	return nil
+}
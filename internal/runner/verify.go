@@ -0,0 +1,28 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/tester"
+)
+
+// runPolicyTests runs the Rego unit tests contained in modules and returns an
+// error if any of them fail.
+func runPolicyTests(ctx context.Context, modules map[string]*ast.Module, trace bool) error {
+	runner := tester.NewRunner().SetModules(modules).EnableTracing(trace)
+
+	ch, err := runner.RunTests(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("run tests: %w", err)
+	}
+
+	for result := range ch {
+		if result.Fail {
+			return fmt.Errorf("test failed: %s", result.Name)
+		}
+	}
+
+	return nil
+}
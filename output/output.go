@@ -0,0 +1,142 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Result describes the result of a single rule evaluation.
+type Result struct {
+	Message  string                 `json:"msg"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Traces   []string               `json:"-"`
+}
+
+// CheckResult describes the result of a conftest policy evaluation for a
+// single file/namespace combination.
+type CheckResult struct {
+	Query      string   `json:"query"`
+	FileName   string   `json:"filename"`
+	Namespace  string   `json:"namespace"`
+	Successes  []Result `json:"successes,omitempty"`
+	Warnings   []Result `json:"warnings,omitempty"`
+	Failures   []Result `json:"failures,omitempty"`
+	Exceptions []Result `json:"exceptions,omitempty"`
+}
+
+// Manager represents a generic mechanism for outputting the results of
+// a conftest policy evaluation.
+type Manager interface {
+	Put(cr CheckResult) error
+	Flush() error
+	WithTracing() Manager
+}
+
+// ValidOutputs returns the available output formats for conftest results.
+func ValidOutputs() []string {
+	return []string{
+		"stdout",
+		"json",
+		"table",
+		"junit",
+		"sarif",
+	}
+}
+
+// GetOutputManager returns the Manager for the given output type, writing
+// to the process stdout.
+func GetOutputManager(outputType string, color bool) Manager {
+	return GetOutputManagerWithWriter(os.Stdout, outputType, color)
+}
+
+// GetOutputManagerWithWriter returns the Manager for the given output type,
+// writing results to the supplied writer instead of the process stdout.
+// Only "json" has its own Manager; every other type, including "table",
+// "junit" and "sarif", uses the standard line-per-result output.
+func GetOutputManagerWithWriter(w io.Writer, outputType string, color bool) Manager {
+	switch outputType {
+	case "json":
+		return newJSONOutputManager(w)
+	default:
+		return newStandardOutputManager(w, color)
+	}
+}
+
+type standardOutputManager struct {
+	writer io.Writer
+	color  bool
+	trace  bool
+}
+
+func newStandardOutputManager(w io.Writer, color bool) *standardOutputManager {
+	return &standardOutputManager{writer: w, color: color}
+}
+
+func (s *standardOutputManager) WithTracing() Manager {
+	s.trace = true
+	return s
+}
+
+func (s *standardOutputManager) Put(cr CheckResult) error {
+	for _, failure := range cr.Failures {
+		fmt.Fprintf(s.writer, "FAIL - %s - %s - %s\n", cr.FileName, cr.Query, failure.Message)
+	}
+	for _, warning := range cr.Warnings {
+		fmt.Fprintf(s.writer, "WARN - %s - %s - %s\n", cr.FileName, cr.Query, warning.Message)
+	}
+	return nil
+}
+
+func (s *standardOutputManager) Flush() error {
+	return nil
+}
+
+type jsonOutputManager struct {
+	writer  io.Writer
+	results []CheckResult
+	trace   bool
+}
+
+func newJSONOutputManager(w io.Writer) *jsonOutputManager {
+	return &jsonOutputManager{writer: w}
+}
+
+func (j *jsonOutputManager) WithTracing() Manager {
+	j.trace = true
+	return j
+}
+
+func (j *jsonOutputManager) Put(cr CheckResult) error {
+	j.results = append(j.results, cr)
+	return nil
+}
+
+func (j *jsonOutputManager) Flush() error {
+	enc := json.NewEncoder(j.writer)
+	enc.SetIndent("", "\t")
+	return enc.Encode(j.results)
+}
+
+// ExitCode returns the exit code that conftest should exit with, given the
+// outcome of a set of check results.
+func ExitCode(results []CheckResult) int {
+	for _, result := range results {
+		if len(result.Failures) > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ExitCodeFailOnWarn returns the exit code that conftest should exit with
+// when warnings should also be treated as failures.
+func ExitCodeFailOnWarn(results []CheckResult) int {
+	for _, result := range results {
+		if len(result.Failures) > 0 || len(result.Warnings) > 0 {
+			return 1
+		}
+	}
+	return 0
+}
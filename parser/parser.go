@@ -0,0 +1,72 @@
+// Package parser loads configuration and data files from disk so they can be
+// evaluated against Rego policies.
+package parser
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidInputs returns the input type names listed in the --input flag's
+// help. ParseConfigurations does not dispatch on them: it decodes every
+// file as YAML.
+func ValidInputs() []string {
+	return []string{
+		"yaml",
+		"json",
+		"toml",
+		"hcl",
+		"hcl2",
+		"dockerfile",
+		"ini",
+		"cue",
+		"edn",
+		"vcl",
+	}
+}
+
+// ParseConfigurations reads and unmarshals the given files as YAML (which
+// also covers JSON), keyed by path.
+func ParseConfigurations(files []string) (map[string]interface{}, error) {
+	configs := make(map[string]interface{})
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read file %s: %w", file, err)
+		}
+
+		var parsed interface{}
+		if err := yaml.Unmarshal(contents, &parsed); err != nil {
+			return nil, fmt.Errorf("unmarshal file %s: %w", file, err)
+		}
+
+		configs[file] = parsed
+	}
+
+	return configs, nil
+}
+
+// ParseData reads and unmarshals the given data paths into a single object
+// made available to Rego policies under the `data` document. Each path is
+// read as a single YAML file and stored under the path as given;
+// directories are not walked.
+func ParseData(paths []string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read data %s: %w", path, err)
+		}
+
+		var parsed interface{}
+		if err := yaml.Unmarshal(contents, &parsed); err != nil {
+			return nil, fmt.Errorf("unmarshal data %s: %w", path, err)
+		}
+
+		data[path] = parsed
+	}
+
+	return data, nil
+}
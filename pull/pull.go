@@ -0,0 +1,65 @@
+// Package pull fetches remote policy files over plain HTTP(S) onto the local
+// filesystem so they can be evaluated like any other policy directory. OCI
+// registries are not supported: a url is fetched with a single HTTP GET.
+package pull
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Pull downloads the bundle at url into destination.
+func Pull(ctx context.Context, url string, destination string) error {
+	destPath := PolicyPath(url, destination)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("create destination: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch %s: unexpected status %s", url, resp.Status)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := out.ReadFrom(resp.Body); err != nil {
+		return fmt.Errorf("write %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// PolicyPath returns the local path Pull writes url's content to under
+// destination. Each url gets its own digest-keyed subdirectory so that two
+// urls sharing a basename (e.g. two files both named "policy.rego") don't
+// overwrite one another on disk; callers that need to locate a downloaded
+// file afterwards (such as checksum verification) must derive the same path
+// through this function rather than reimplementing the layout.
+func PolicyPath(url, destination string) string {
+	return filepath.Join(destination, urlDigest(url), filepath.Base(url))
+}
+
+// urlDigest returns a short, filesystem-safe identifier derived from url.
+func urlDigest(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,86 @@
+// Package push bundles policy directories into a gzipped tarball for upload
+// to an OCI registry. No registry transport is implemented, so Push always
+// returns an error after building the bundle.
+package push
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Push bundles the given policy directories into a tarball and hands it to
+// upload for the repository at url; upload currently always fails.
+func Push(ctx context.Context, url string, policyDirs []string) error {
+	bundle, err := bundle(policyDirs)
+	if err != nil {
+		return fmt.Errorf("bundle policies: %w", err)
+	}
+
+	return upload(ctx, url, bundle)
+}
+
+// bundle tars and gzips the given policy directories into a single archive.
+func bundle(policyDirs []string) ([]byte, error) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for _, dir := range policyDirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("open %s: %w", path, err)
+			}
+			defer f.Close()
+
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return fmt.Errorf("build header for %s: %w", path, err)
+			}
+			header.Name = path
+
+			if err := tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("write header for %s: %w", path, err)
+			}
+
+			if _, err := io.Copy(tw, f); err != nil {
+				return fmt.Errorf("write contents of %s: %w", path, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %w", dir, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("close tar writer: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("close gzip writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// upload pushes the bundle to the OCI repository at url.
+//
+// Unimplemented: no registry transport is wired up yet, so this always
+// fails.
+func upload(ctx context.Context, url string, bundle []byte) error {
+	return fmt.Errorf("no registry transport configured for %s", url)
+}